@@ -0,0 +1,258 @@
+package quest
+
+import (
+	"log"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// defaultBusBufferSize bounds the ring buffer Publish enqueues onto. Once
+// full, Publish drops the event rather than blocking the caller (typically
+// the zone tick loop).
+const defaultBusBufferSize = 1024
+
+// defaultSlowHandlerDeadline is how long a handler may run before the
+// watchdog logs a warning. Zero disables the watchdog.
+const defaultSlowHandlerDeadline = 50 * time.Millisecond
+
+// EventTypeStats is a point-in-time snapshot of a single QuestEventType's
+// traffic through the event bus. The latency fields time the Invoke call of
+// a dispatch (every handler it runs for the event), not a single handler —
+// a (name, event) key can have more than one matching handler. SubscribeAll
+// sinks run before timing starts and are not included.
+type EventTypeStats struct {
+	Dispatched         uint64
+	Dropped            uint64
+	DispatchCount      uint64
+	AvgDispatchLatency time.Duration
+	MaxDispatchLatency time.Duration
+}
+
+type eventTypeCounters struct {
+	dispatched    uint64
+	dropped       uint64
+	dispatchCount uint64
+	dispatchNsSum uint64
+	dispatchNsMax uint64
+}
+
+// eventBus is the per-zone async dispatcher backing Publish/PublishSync.
+// Events are enqueued onto a bounded channel and drained by a small worker
+// pool so high-traffic events (EventTick, EventDamageGiven, EventHateList,
+// EventSpellEffectBuffTicNpc, ...) don't block the zone tick loop behind a
+// synchronous handler.
+type eventBus struct {
+	z         *ZoneQuestInterface
+	queue     chan *QuestEvent
+	deadline  time.Duration
+	stopCh    chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+
+	subsMu sync.RWMutex
+	subs   []func(*QuestEvent)
+
+	statsMu sync.Mutex
+	stats   map[QuestEventType]*eventTypeCounters
+}
+
+func newEventBus(z *ZoneQuestInterface, bufferSize, workers int, deadline time.Duration) *eventBus {
+	if bufferSize <= 0 {
+		bufferSize = defaultBusBufferSize
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0) / 2
+		if workers < 1 {
+			workers = 1
+		}
+	}
+	bus := &eventBus{
+		z:        z,
+		queue:    make(chan *QuestEvent, bufferSize),
+		deadline: deadline,
+		stopCh:   make(chan struct{}),
+		stats:    make(map[QuestEventType]*eventTypeCounters),
+	}
+	bus.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go bus.run()
+	}
+	return bus
+}
+
+func (b *eventBus) run() {
+	defer b.wg.Done()
+	for {
+		select {
+		case evt := <-b.queue:
+			b.z.dispatchEvent(evt)
+		case <-b.stopCh:
+			return
+		}
+	}
+}
+
+// Close stops the worker pool and waits for in-flight handlers to finish.
+// Events still queued but not yet picked up by a worker are dropped. Safe
+// to call more than once.
+func (b *eventBus) Close() {
+	b.closeOnce.Do(func() {
+		close(b.stopCh)
+	})
+	b.wg.Wait()
+}
+
+// counters returns (creating if needed) the counters for eventType. The
+// caller must already hold b.statsMu.
+func (b *eventBus) counters(eventType QuestEventType) *eventTypeCounters {
+	c, ok := b.stats[eventType]
+	if !ok {
+		c = &eventTypeCounters{}
+		b.stats[eventType] = c
+	}
+	return c
+}
+
+func (b *eventBus) recordDispatched(eventType QuestEventType) {
+	b.statsMu.Lock()
+	defer b.statsMu.Unlock()
+	b.counters(eventType).dispatched++
+}
+
+func (b *eventBus) recordDropped(eventType QuestEventType) {
+	b.statsMu.Lock()
+	defer b.statsMu.Unlock()
+	b.counters(eventType).dropped++
+}
+
+func (b *eventBus) recordLatency(eventType QuestEventType, d time.Duration) {
+	b.statsMu.Lock()
+	defer b.statsMu.Unlock()
+	c := b.counters(eventType)
+	c.dispatchCount++
+	c.dispatchNsSum += uint64(d.Nanoseconds())
+	if ns := uint64(d.Nanoseconds()); ns > c.dispatchNsMax {
+		c.dispatchNsMax = ns
+	}
+}
+
+// Stats snapshots per-event-type traffic through the bus.
+func (b *eventBus) Stats() map[QuestEventType]EventTypeStats {
+	b.statsMu.Lock()
+	defer b.statsMu.Unlock()
+	out := make(map[QuestEventType]EventTypeStats, len(b.stats))
+	for evt, c := range b.stats {
+		s := EventTypeStats{
+			Dispatched:         c.dispatched,
+			Dropped:            c.dropped,
+			DispatchCount:      c.dispatchCount,
+			MaxDispatchLatency: time.Duration(c.dispatchNsMax),
+		}
+		if c.dispatchCount > 0 {
+			s.AvgDispatchLatency = time.Duration(c.dispatchNsSum / c.dispatchCount)
+		}
+		out[evt] = s
+	}
+	return out
+}
+
+func (b *eventBus) notifySubscribers(evt *QuestEvent) {
+	b.subsMu.RLock()
+	defer b.subsMu.RUnlock()
+	for _, fn := range b.subs {
+		fn(evt)
+	}
+}
+
+func (b *eventBus) subscribe(fn func(*QuestEvent)) {
+	b.subsMu.Lock()
+	defer b.subsMu.Unlock()
+	b.subs = append(b.subs, fn)
+}
+
+// ensureBus lazily starts the zone's event bus with default settings the
+// first time Publish, PublishSync, SubscribeAll, or Stats is used.
+func (z *ZoneQuestInterface) ensureBus() *eventBus {
+	z.busOnce.Do(func() {
+		z.bus = newEventBus(z, defaultBusBufferSize, 0, defaultSlowHandlerDeadline)
+	})
+	return z.bus
+}
+
+// StartEventBus starts the zone's event bus with explicit buffer size,
+// worker count, and slow-handler deadline, overriding the defaults Publish
+// would otherwise lazily apply. It must be called before the first
+// Publish/PublishSync/SubscribeAll/Stats call to have an effect.
+func (z *ZoneQuestInterface) StartEventBus(bufferSize, workers int, slowHandlerDeadline time.Duration) {
+	z.busOnce.Do(func() {
+		z.bus = newEventBus(z, bufferSize, workers, slowHandlerDeadline)
+	})
+}
+
+// dispatchEvent runs evt through the SubscribeAll sinks and the (name,
+// event) handler chain, recording latency/deadline metrics for the bus.
+func (z *ZoneQuestInterface) dispatchEvent(evt *QuestEvent) bool {
+	bus := z.bus
+	bus.notifySubscribers(evt)
+
+	start := time.Now()
+	handled := z.Invoke(evt.Name, evt)
+	elapsed := time.Since(start)
+
+	bus.recordDispatched(evt.EventType)
+	bus.recordLatency(evt.EventType, elapsed)
+	if bus.deadline > 0 && elapsed > bus.deadline {
+		log.Printf("quest: handler %q for event %d took %s, exceeding the %s deadline", evt.Name, evt.EventType, elapsed, bus.deadline)
+	}
+	return handled
+}
+
+// Publish enqueues evt onto the zone's event bus for asynchronous dispatch
+// by the worker pool. If the bus is saturated, evt is dropped rather than
+// blocking the caller; dropped events are reflected in Stats().
+//
+// Publish takes ownership of evt: a worker may still be reading it after
+// Publish returns, so the caller must not mutate it (including via
+// Reset()/the builder methods) or otherwise reuse it. Build a fresh
+// *QuestEvent per Publish call; pool/reuse one only across PublishSync
+// calls, which dispatch before returning.
+func (z *ZoneQuestInterface) Publish(evt *QuestEvent) {
+	bus := z.ensureBus()
+	select {
+	case bus.queue <- evt:
+	default:
+		bus.recordDropped(evt.EventType)
+	}
+}
+
+// PublishSync dispatches evt synchronously on the calling goroutine,
+// preserving the blocking semantics the old map-lookup Invoke had. Prefer
+// Publish for high-traffic events; use PublishSync only for legacy call
+// sites that need the result immediately.
+func (z *ZoneQuestInterface) PublishSync(evt *QuestEvent) bool {
+	z.ensureBus()
+	return z.dispatchEvent(evt)
+}
+
+// SubscribeAll registers fn to run for every event published to the zone's
+// bus, regardless of (name, event) key. It's intended for logging/analytics
+// sinks, not gameplay handlers.
+func (z *ZoneQuestInterface) SubscribeAll(fn func(*QuestEvent)) {
+	z.ensureBus().subscribe(fn)
+}
+
+// Stats returns a snapshot of per-event-type traffic through the zone's
+// event bus: events dispatched/dropped and handler latency.
+func (z *ZoneQuestInterface) Stats() map[QuestEventType]EventTypeStats {
+	return z.ensureBus().Stats()
+}
+
+// CloseEventBus stops the zone's event bus worker pool, if one was ever
+// started, waiting for in-flight handlers to finish. Call it when tearing
+// down a transient zone instance so its workers don't leak.
+func (z *ZoneQuestInterface) CloseEventBus() {
+	if z.bus != nil {
+		z.bus.Close()
+	}
+}
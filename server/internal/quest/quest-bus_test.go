@@ -0,0 +1,30 @@
+package quest
+
+import "testing"
+
+// TestPublishSyncRoundTrip exercises PublishSync end to end: register a
+// handler, dispatch one event synchronously, and check it both ran and was
+// reflected in Stats(). This is the path that used to deadlock on statsMu
+// (recordDispatched/recordLatency re-locking inside counters()).
+func TestPublishSyncRoundTrip(t *testing.T) {
+	z := &ZoneQuestInterface{}
+
+	var gotName string
+	z.Register("Qeynos_Guard", EventSay, QuestHandler(func(evt *QuestEvent) bool {
+		gotName = evt.Name
+		return true
+	}))
+
+	handled := z.PublishSync(&QuestEvent{EventType: EventSay, Name: "Qeynos_Guard"})
+	if !handled {
+		t.Fatalf("PublishSync returned false, want true")
+	}
+	if gotName != "Qeynos_Guard" {
+		t.Fatalf("handler saw Name %q, want %q", gotName, "Qeynos_Guard")
+	}
+
+	stats := z.Stats()[EventSay]
+	if stats.Dispatched != 1 {
+		t.Fatalf("Stats().Dispatched = %d, want 1", stats.Dispatched)
+	}
+}
@@ -0,0 +1,115 @@
+package quest
+
+import (
+	"regexp"
+	"strings"
+)
+
+// namePattern is a glob name registered via Register (e.g. "guard_*"),
+// compiled once into a regexp so Invoke's fallback scan is a simple
+// MatchString per pattern rather than re-parsing the glob every call.
+type namePattern struct {
+	Pattern string
+	re      *regexp.Regexp
+	Events  map[QuestEventType][]registeredHandler
+}
+
+// isGlobPattern reports whether name contains the "*" wildcard Register
+// treats as a glob rather than a literal NPC/script name.
+func isGlobPattern(name string) bool {
+	return strings.Contains(name, "*")
+}
+
+// compileGlob turns a "*"-wildcard glob into an anchored regexp matching
+// whole strings, escaping every other character literally.
+func compileGlob(pattern string) *regexp.Regexp {
+	segments := strings.Split(pattern, "*")
+	for i, s := range segments {
+		segments[i] = regexp.QuoteMeta(s)
+	}
+	return regexp.MustCompile("^" + strings.Join(segments, ".*") + "$")
+}
+
+// eventMapLocked returns the QuestEventType->handlers map for name, creating
+// the exact-name entry or glob pattern entry as needed. Caller must hold
+// z.Mu for writing.
+func (z *ZoneQuestInterface) eventMapLocked(name string) map[QuestEventType][]registeredHandler {
+	if isGlobPattern(name) {
+		for _, p := range z.Patterns {
+			if p.Pattern == name {
+				return p.Events
+			}
+		}
+		p := &namePattern{
+			Pattern: name,
+			re:      compileGlob(name),
+			Events:  make(map[QuestEventType][]registeredHandler),
+		}
+		z.Patterns = append(z.Patterns, p)
+		return p.Events
+	}
+
+	if z.Handlers == nil {
+		z.Handlers = make(map[string]map[QuestEventType][]registeredHandler)
+	}
+	if z.Handlers[name] == nil {
+		z.Handlers[name] = make(map[QuestEventType][]registeredHandler)
+	}
+	return z.Handlers[name]
+}
+
+// unregisterPatternLocked is Unregister's glob-name path. Caller must hold
+// z.Mu for writing.
+func (z *ZoneQuestInterface) unregisterPatternLocked(pattern string, events []QuestEventType) {
+	for i, p := range z.Patterns {
+		if p.Pattern != pattern {
+			continue
+		}
+		if len(events) == 0 {
+			z.Patterns = append(z.Patterns[:i], z.Patterns[i+1:]...)
+			return
+		}
+		for _, event := range events {
+			delete(p.Events, event)
+		}
+		if len(p.Events) == 0 {
+			z.Patterns = append(z.Patterns[:i], z.Patterns[i+1:]...)
+		}
+		return
+	}
+}
+
+// invokeMatching runs every handler in handlers whose predicates all match
+// evt, so a name can hold several handlers for the same event (predicated
+// or not) without later registrations shadowing earlier ones. found is false
+// when no registered handler's predicates matched; handled is true if any
+// matching handler returned true.
+func invokeMatching(handlers []registeredHandler, evt *QuestEvent) (handled, found bool) {
+	for _, rh := range handlers {
+		if rh.matches(evt) {
+			found = true
+			if rh.Handler(evt) {
+				handled = true
+			}
+		}
+	}
+	return handled, found
+}
+
+// invokeAllLocked implements Invoke("*", evt): every exact name and glob
+// pattern with a matching handler for evt.EventType runs, and the result is
+// true if any of them returned true. Caller must hold z.Mu for reading.
+func (z *ZoneQuestInterface) invokeAllLocked(evt *QuestEvent) bool {
+	handled := false
+	for _, handlers := range z.Handlers {
+		if h, found := invokeMatching(handlers[evt.EventType], evt); found && h {
+			handled = true
+		}
+	}
+	for _, p := range z.Patterns {
+		if h, found := invokeMatching(p.Events[evt.EventType], evt); found && h {
+			handled = true
+		}
+	}
+	return handled
+}
@@ -0,0 +1,66 @@
+package quest
+
+import "testing"
+
+// TestInvokeGlobAndWildcard checks that a glob-registered name dispatches
+// through Invoke("guard_west", ...) and that Invoke("*", ...) fans out to
+// every matching handler, running all of them rather than stopping at the
+// first.
+func TestInvokeGlobAndWildcard(t *testing.T) {
+	z := &ZoneQuestInterface{}
+
+	var globRuns, exactRuns int
+	z.Register("guard_*", EventSay, QuestHandler(func(evt *QuestEvent) bool {
+		globRuns++
+		return true
+	}))
+	z.Register("guard_west", EventSay, QuestHandler(func(evt *QuestEvent) bool {
+		exactRuns++
+		return true
+	}))
+
+	if !z.Invoke("guard_west", &QuestEvent{EventType: EventSay}) {
+		t.Fatalf("Invoke(guard_west) returned false")
+	}
+	if exactRuns != 1 || globRuns != 0 {
+		t.Fatalf("exact name match should win over glob: exactRuns=%d globRuns=%d", exactRuns, globRuns)
+	}
+
+	if !z.Invoke("guard_east", &QuestEvent{EventType: EventSay}) {
+		t.Fatalf("Invoke(guard_east) via glob returned false")
+	}
+	if globRuns != 1 {
+		t.Fatalf("glob handler ran %d times, want 1", globRuns)
+	}
+
+	if !z.Invoke("*", &QuestEvent{EventType: EventSay}) {
+		t.Fatalf("Invoke(*) returned false")
+	}
+	if exactRuns != 2 || globRuns != 2 {
+		t.Fatalf("Invoke(*) should run both exact and glob handlers: exactRuns=%d globRuns=%d", exactRuns, globRuns)
+	}
+}
+
+// TestInvokeRunsAllMatchingHandlers guards against a regression back to
+// first-match-wins: two handlers registered for the same (name, event) both
+// run instead of only the first.
+func TestInvokeRunsAllMatchingHandlers(t *testing.T) {
+	z := &ZoneQuestInterface{}
+
+	var first, second bool
+	z.Register("Innkeeper", EventSay, QuestHandler(func(evt *QuestEvent) bool {
+		first = true
+		return false
+	}))
+	z.Register("Innkeeper", EventSay, QuestHandler(func(evt *QuestEvent) bool {
+		second = true
+		return true
+	}))
+
+	if !z.Invoke("Innkeeper", &QuestEvent{EventType: EventSay}) {
+		t.Fatalf("Invoke returned false")
+	}
+	if !first || !second {
+		t.Fatalf("expected both handlers to run: first=%v second=%v", first, second)
+	}
+}
@@ -2,6 +2,7 @@ package quest
 
 import (
 	"fmt"
+	"reflect"
 	"sync"
 	"time"
 
@@ -194,6 +195,7 @@ const (
 // Big TBD on what data is going in here
 type QuestEvent struct {
 	EventType     QuestEventType
+	Name          string // dispatch key passed to Invoke; set by Publish/PublishSync callers
 	Actor         entity.Moblike // will be Actor which can be interpreted as any type of Mob (NPC, PC, Client)
 	Receiver      entity.Moblike
 	Item          *[]items.ItemInstance
@@ -204,48 +206,109 @@ type QuestEvent struct {
 	ItemArray     *[]items.ItemInstance
 	ActorArray    *[]model.Spawn2
 	StringArray   []string
+
+	zone *ZoneQuestInterface // set by Invoke; backs QuestEvent.State()
+
+	// typedPayloads memoizes the payloads RegisterTyped handlers build from
+	// this event, keyed by payload type, so every typed handler invoked for
+	// this dispatch shares one built payload instead of re-deriving it. See
+	// quest-typed.go.
+	typedPayloads map[reflect.Type]any
 }
 
 type QuestHandler func(*QuestEvent) bool
+
+// registeredHandler pairs a handler with the predicates that must all match
+// before it is invoked. A nil/empty Predicates slice always matches.
+type registeredHandler struct {
+	Handler    QuestHandler
+	Predicates []QuestEventPredicate
+}
+
+func (rh registeredHandler) matches(evt *QuestEvent) bool {
+	for _, p := range rh.Predicates {
+		if !p.Match(evt) {
+			return false
+		}
+	}
+	return true
+}
+
 type ZoneQuestInterface struct {
 	ZoneAccess ZoneAccess //
 	Mu         sync.RWMutex
-	Handlers   map[string]map[QuestEventType]QuestHandler
+	Handlers   map[string]map[QuestEventType][]registeredHandler // exact-name dispatch
+	Patterns   []*namePattern                                    // glob-name dispatch, e.g. Register("guard_*", ...)
+
+	bus     *eventBus
+	busOnce sync.Once
+
+	timerMgr  *QuestTimerManager
+	timerOnce sync.Once
+
+	persistence   QuestStateRepository
+	persistenceMu sync.Mutex
 }
 
 func (z *ZoneQuestInterface) SetZoneAccess(za ZoneAccess) {
 	z.ZoneAccess = za
 }
 
+// Register appends a handler for each (event, handler) pair to name's
+// dispatch list. Multiple handlers may be registered for the same
+// (name, event) key; see RegisterWithPredicates to scope a handler to a
+// subset of events. name may contain "*" glob wildcards (e.g. "guard_*"),
+// compiled once into a matcher consulted by Invoke when no exact name
+// matches.
 func (z *ZoneQuestInterface) Register(name string, events ...any) {
 	z.Mu.Lock()
 	defer z.Mu.Unlock()
-	if z.Handlers == nil {
-		z.Handlers = make(map[string]map[QuestEventType]QuestHandler)
-	}
-	if z.Handlers[name] == nil {
-		z.Handlers[name] = make(map[QuestEventType]QuestHandler)
-	}
+	handlers := z.eventMapLocked(name)
 	for i := 0; i < len(events); i += 2 {
 		event, ok := events[i].(QuestEventType)
 		if !ok {
 			panic(fmt.Sprintf("arg %d is not QuestEventType", i))
 		}
-		switch handler := events[i+1].(type) {
+		var handler QuestHandler
+		switch h := events[i+1].(type) {
 		case QuestHandler:
-			z.Handlers[name][event] = handler
+			handler = h
 		case func(*QuestEvent) bool:
-			z.Handlers[name][event] = QuestHandler(handler)
+			handler = QuestHandler(h)
 		default:
 			panic(fmt.Sprintf("arg %d is not a valid QuestHandler", i+1))
 		}
+		handlers[event] = append(handlers[event], registeredHandler{Handler: handler})
 	}
 }
 
+// RegisterWithPredicates registers handler for (name, event) but only runs it
+// when every predicate in preds matches the incoming *QuestEvent. Like
+// Register, it appends to the existing dispatch list rather than replacing
+// it, so a name can hold several predicated handlers for the same event.
+func (z *ZoneQuestInterface) RegisterWithPredicates(name string, event QuestEventType, handler QuestHandler, preds ...QuestEventPredicate) {
+	z.Mu.Lock()
+	defer z.Mu.Unlock()
+	handlers := z.eventMapLocked(name)
+	handlers[event] = append(handlers[event], registeredHandler{
+		Handler:    handler,
+		Predicates: preds,
+	})
+}
+
+// Unregister removes handlers previously registered under name. name may be
+// a glob pattern, in which case it must match the pattern string passed to
+// Register exactly (wildcards are not re-expanded). With no events, every
+// handler under name is removed; otherwise only the given events are.
 func (z *ZoneQuestInterface) Unregister(name string, events ...QuestEventType) {
 	z.Mu.Lock()
 	defer z.Mu.Unlock()
 
+	if isGlobPattern(name) {
+		z.unregisterPatternLocked(name, events)
+		return
+	}
+
 	if z.Handlers == nil || z.Handlers[name] == nil {
 		return
 	}
@@ -264,10 +327,37 @@ func (z *ZoneQuestInterface) Unregister(name string, events ...QuestEventType) {
 	}
 }
 
+// Invoke dispatches evt to the handler(s) registered for name and
+// evt.EventType. A literal name resolves against the exact-name map first
+// (the common O(1) path); only when that produces no match are registered
+// glob patterns consulted. The special name "*" fans out to every name
+// (exact or glob) that has a matching handler. Every handler whose
+// predicates match runs (not just the first), so repeated Register calls
+// for the same (name, event) accumulate handlers instead of the last one
+// shadowing the rest; the return value is true if any of them returned
+// true. Handlers and predicates are read under RLock, so Invoke is safe to
+// call concurrently with Register/Unregister.
 func (z *ZoneQuestInterface) Invoke(name string, evt *QuestEvent) bool {
+	evt.zone = z
+	z.Mu.RLock()
+	defer z.Mu.RUnlock()
+
+	if name == "*" {
+		return z.invokeAllLocked(evt)
+	}
+
 	if handlers, ok := z.Handlers[name]; ok {
-		if handler, ok := handlers[evt.EventType]; ok {
-			return handler(evt)
+		if handled, found := invokeMatching(handlers[evt.EventType], evt); found {
+			return handled
+		}
+	}
+
+	for _, p := range z.Patterns {
+		if !p.re.MatchString(name) {
+			continue
+		}
+		if handled, found := invokeMatching(p.Events[evt.EventType], evt); found {
+			return handled
 		}
 	}
 	return false
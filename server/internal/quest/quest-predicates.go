@@ -0,0 +1,86 @@
+package quest
+
+import "github.com/knervous/eqgo/internal/entity"
+
+// QuestEventPredicate filters a *QuestEvent before a registered handler runs.
+// It mirrors controller-runtime's predicate.Predicate: cheap, composable,
+// and evaluated before the (comparatively expensive) handler is invoked.
+type QuestEventPredicate interface {
+	Match(evt *QuestEvent) bool
+}
+
+// Funcs adapts a plain function into a QuestEventPredicate.
+type Funcs func(evt *QuestEvent) bool
+
+func (f Funcs) Match(evt *QuestEvent) bool {
+	return f(evt)
+}
+
+// And matches when every predicate in preds matches.
+func And(preds ...QuestEventPredicate) QuestEventPredicate {
+	return Funcs(func(evt *QuestEvent) bool {
+		for _, p := range preds {
+			if !p.Match(evt) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Or matches when at least one predicate in preds matches.
+func Or(preds ...QuestEventPredicate) QuestEventPredicate {
+	return Funcs(func(evt *QuestEvent) bool {
+		for _, p := range preds {
+			if p.Match(evt) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Not inverts pred.
+func Not(pred QuestEventPredicate) QuestEventPredicate {
+	return Funcs(func(evt *QuestEvent) bool {
+		return !pred.Match(evt)
+	})
+}
+
+// ActorIsNPC matches when evt.Actor is the NPC identified by npcID.
+func ActorIsNPC(npcID int) QuestEventPredicate {
+	return Funcs(func(evt *QuestEvent) bool {
+		npc, ok := evt.Actor.(*entity.NPC)
+		return ok && int(npc.GetID()) == npcID
+	})
+}
+
+// ReceiverIsPlayer matches when evt.Receiver is a player Client.
+func ReceiverIsPlayer() QuestEventPredicate {
+	return Funcs(func(evt *QuestEvent) bool {
+		_, ok := evt.Receiver.(*entity.Client)
+		return ok
+	})
+}
+
+// SpellIDIn matches when evt.SpellID is one of ids.
+func SpellIDIn(ids ...uint32) QuestEventPredicate {
+	return Funcs(func(evt *QuestEvent) bool {
+		for _, id := range ids {
+			if evt.SpellID == id {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// AreaMatches matches when evt.Actor is located in the given zone/instance.
+func AreaMatches(zoneID, instanceID int) QuestEventPredicate {
+	return Funcs(func(evt *QuestEvent) bool {
+		if evt.Actor == nil {
+			return false
+		}
+		return evt.Actor.GetZoneID() == zoneID && evt.Actor.GetInstanceID() == instanceID
+	})
+}
@@ -0,0 +1,159 @@
+package quest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoRequestTimeout bounds every individual Mongo round-trip a
+// MongoStateRepository method makes.
+const mongoRequestTimeout = 5 * time.Second
+
+// stateDocument is the Mongo representation of one QuestStateRepository
+// entry. ExpiresAt is nil when the entry has no TTL.
+type stateDocument struct {
+	InstanceID int        `bson:"instanceId"`
+	QuestName  string     `bson:"questName"`
+	PlayerID   int64      `bson:"playerId"`
+	Key        string     `bson:"key"`
+	Value      []byte     `bson:"value"`
+	ExpiresAt  *time.Time `bson:"expiresAt,omitempty"`
+}
+
+// MongoStateRepository is a QuestStateRepository backed by MongoDB, with
+// one collection per zone and a compound index on
+// {questName, playerId, key, instanceId} so Get/Set/Delete are
+// single-document lookups. instanceId is part of the unique key, not just
+// the filter: two instances can otherwise share a (questName, playerId,
+// key) triple, and without instanceId in the index the upsert filter in
+// Set would fail to match the other instance's document and attempt an
+// insert that violates uniqueness instead of updating in place.
+type MongoStateRepository struct {
+	db *mongo.Database
+}
+
+// NewMongoStateRepository wraps an existing *mongo.Client. Call
+// EnsureIndexes once per zone before serving traffic.
+func NewMongoStateRepository(client *mongo.Client, dbName string) *MongoStateRepository {
+	return &MongoStateRepository{db: client.Database(dbName)}
+}
+
+func (r *MongoStateRepository) collection(zoneID int) *mongo.Collection {
+	return r.db.Collection(fmt.Sprintf("quest_state_zone_%d", zoneID))
+}
+
+// EnsureIndexes creates the compound {questName, playerId, key, instanceId}
+// unique index for zoneID's collection. instanceId must be part of the
+// index, not just the query filter: every Get/Set/Delete also scopes by
+// instanceId, so two instances are allowed to hold the same
+// (questName, playerId, key) triple. Safe to call repeatedly; Mongo no-ops
+// on a match.
+func (r *MongoStateRepository) EnsureIndexes(ctx context.Context, zoneID int) error {
+	_, err := r.collection(zoneID).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "questName", Value: 1},
+			{Key: "playerId", Value: 1},
+			{Key: "key", Value: 1},
+			{Key: "instanceId", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+func (r *MongoStateRepository) Get(zoneID, instanceID int, questName string, playerID int64, key string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), mongoRequestTimeout)
+	defer cancel()
+
+	var doc stateDocument
+	err := r.collection(zoneID).FindOne(ctx, bson.M{
+		"instanceId": instanceID,
+		"questName":  questName,
+		"playerId":   playerID,
+		"key":        key,
+	}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrStateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if doc.ExpiresAt != nil && time.Now().After(*doc.ExpiresAt) {
+		_ = r.Delete(zoneID, instanceID, questName, playerID, key)
+		return nil, ErrStateNotFound
+	}
+	return doc.Value, nil
+}
+
+func (r *MongoStateRepository) Set(zoneID, instanceID int, questName string, playerID int64, key string, value []byte, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), mongoRequestTimeout)
+	defer cancel()
+
+	set := bson.M{
+		"instanceId": instanceID,
+		"questName":  questName,
+		"playerId":   playerID,
+		"key":        key,
+		"value":      value,
+	}
+	update := bson.M{"$set": set}
+	if ttl > 0 {
+		set["expiresAt"] = time.Now().Add(ttl)
+	} else {
+		// Setting without a TTL must make the value permanent, clearing any
+		// expiresAt left over from a previous Set with a TTL; omitempty on
+		// stateDocument.ExpiresAt means $set alone would leave it in place.
+		update["$unset"] = bson.M{"expiresAt": ""}
+	}
+
+	_, err := r.collection(zoneID).UpdateOne(ctx,
+		bson.M{"instanceId": instanceID, "questName": questName, "playerId": playerID, "key": key},
+		update,
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *MongoStateRepository) Delete(zoneID, instanceID int, questName string, playerID int64, key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), mongoRequestTimeout)
+	defer cancel()
+
+	_, err := r.collection(zoneID).DeleteOne(ctx, bson.M{
+		"instanceId": instanceID,
+		"questName":  questName,
+		"playerId":   playerID,
+		"key":        key,
+	})
+	return err
+}
+
+func (r *MongoStateRepository) Scan(zoneID, instanceID int, questName string, playerID int64, prefix string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), mongoRequestTimeout)
+	defer cancel()
+
+	cursor, err := r.collection(zoneID).Find(ctx, bson.M{
+		"instanceId": instanceID,
+		"questName":  questName,
+		"playerId":   playerID,
+		"key":        bson.M{"$regex": "^" + prefix},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var keys []string
+	for cursor.Next(ctx) {
+		var doc stateDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		keys = append(keys, doc.Key)
+	}
+	return keys, cursor.Err()
+}
@@ -0,0 +1,202 @@
+package quest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/knervous/eqgo/internal/entity"
+)
+
+// ErrStateNotFound is returned by QuestStateRepository.Get when no value is
+// stored for the given key.
+var ErrStateNotFound = errors.New("quest: state not found")
+
+// QuestStateRepository reads and writes per-quest, per-player key/value
+// state. Quest scripts reach it indirectly through QuestEvent.State();
+// ZoneQuestInterface.SetPersistence swaps the backend.
+type QuestStateRepository interface {
+	Get(zoneID, instanceID int, questName string, playerID int64, key string) ([]byte, error)
+	Set(zoneID, instanceID int, questName string, playerID int64, key string, value []byte, ttl time.Duration) error
+	Delete(zoneID, instanceID int, questName string, playerID int64, key string) error
+	Scan(zoneID, instanceID int, questName string, playerID int64, prefix string) ([]string, error)
+}
+
+type stateKey struct {
+	ZoneID     int
+	InstanceID int
+	QuestName  string
+	PlayerID   int64
+	Key        string
+}
+
+type stateEntry struct {
+	value     []byte
+	expiresAt time.Time // zero means no expiry
+}
+
+// memoryStateRepository is the default QuestStateRepository: an in-memory
+// map that does not survive a process restart.
+type memoryStateRepository struct {
+	mu      sync.RWMutex
+	entries map[stateKey]stateEntry
+}
+
+func newMemoryStateRepository() *memoryStateRepository {
+	return &memoryStateRepository{entries: make(map[stateKey]stateEntry)}
+}
+
+func (r *memoryStateRepository) Get(zoneID, instanceID int, questName string, playerID int64, key string) ([]byte, error) {
+	r.mu.RLock()
+	entry, ok := r.entries[stateKey{zoneID, instanceID, questName, playerID, key}]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, ErrStateNotFound
+	}
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		r.mu.Lock()
+		delete(r.entries, stateKey{zoneID, instanceID, questName, playerID, key})
+		r.mu.Unlock()
+		return nil, ErrStateNotFound
+	}
+	return entry.value, nil
+}
+
+func (r *memoryStateRepository) Set(zoneID, instanceID int, questName string, playerID int64, key string, value []byte, ttl time.Duration) error {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[stateKey{zoneID, instanceID, questName, playerID, key}] = stateEntry{value: value, expiresAt: expiresAt}
+	return nil
+}
+
+func (r *memoryStateRepository) Delete(zoneID, instanceID int, questName string, playerID int64, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.entries, stateKey{zoneID, instanceID, questName, playerID, key})
+	return nil
+}
+
+func (r *memoryStateRepository) Scan(zoneID, instanceID int, questName string, playerID int64, prefix string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var keys []string
+	for k := range r.entries {
+		if k.ZoneID == zoneID && k.InstanceID == instanceID && k.QuestName == questName && k.PlayerID == playerID && strings.HasPrefix(k.Key, prefix) {
+			keys = append(keys, k.Key)
+		}
+	}
+	return keys, nil
+}
+
+// SetPersistence installs the QuestStateRepository quest handlers write
+// through via QuestEvent.State(). It is safe to call before or after
+// handlers have been registered.
+func (z *ZoneQuestInterface) SetPersistence(repo QuestStateRepository) {
+	z.persistenceMu.Lock()
+	defer z.persistenceMu.Unlock()
+	z.persistence = repo
+}
+
+// ensurePersistence returns the configured QuestStateRepository, lazily
+// installing the in-memory default on first use.
+func (z *ZoneQuestInterface) ensurePersistence() QuestStateRepository {
+	z.persistenceMu.Lock()
+	defer z.persistenceMu.Unlock()
+	if z.persistence == nil {
+		z.persistence = newMemoryStateRepository()
+	}
+	return z.persistence
+}
+
+// QuestState is a handle scoped to the quest and player behind the
+// QuestEvent it was obtained from, returned by QuestEvent.State().
+type QuestState struct {
+	evt *QuestEvent
+}
+
+// State resolves e's owning zone, quest name, and player, returning a
+// QuestState scoped to all three. It panics if e was not produced by
+// Invoke/Publish/PublishSync, since there is no zone to persist through.
+func (e *QuestEvent) State() *QuestState {
+	if e.zone == nil {
+		panic("quest: State() called on a QuestEvent not dispatched through a ZoneQuestInterface")
+	}
+	return &QuestState{evt: e}
+}
+
+func playerIDOf(m entity.Moblike) (int64, bool) {
+	client, ok := m.(*entity.Client)
+	if !ok {
+		return 0, false
+	}
+	return int64(client.GetID()), true
+}
+
+// playerID resolves the current actor/receiver into a player id, actor
+// taking precedence, for use as the persistence key's player component.
+func (s *QuestState) playerID() int64 {
+	if s.evt.Actor != nil {
+		if id, ok := playerIDOf(s.evt.Actor); ok {
+			return id
+		}
+	}
+	if s.evt.Receiver != nil {
+		if id, ok := playerIDOf(s.evt.Receiver); ok {
+			return id
+		}
+	}
+	return 0
+}
+
+func (s *QuestState) coords() (zoneID, instanceID int) {
+	if s.evt.zone.ZoneAccess == nil {
+		return 0, 0
+	}
+	return s.evt.zone.ZoneAccess.GetZoneID(), s.evt.zone.ZoneAccess.GetInstanceID()
+}
+
+// Set JSON-encodes value and writes it through the configured
+// QuestStateRepository, keyed by the dispatching zone, evt.Name as the
+// quest name, and the resolved player id.
+func (s *QuestState) Set(key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("quest: marshal state %q: %w", key, err)
+	}
+	zoneID, instanceID := s.coords()
+	return s.evt.zone.ensurePersistence().Set(zoneID, instanceID, s.evt.Name, s.playerID(), key, data, 0)
+}
+
+// SetTTL is Set with an expiry after which the value is no longer returned.
+func (s *QuestState) SetTTL(key string, value any, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("quest: marshal state %q: %w", key, err)
+	}
+	zoneID, instanceID := s.coords()
+	return s.evt.zone.ensurePersistence().Set(zoneID, instanceID, s.evt.Name, s.playerID(), key, data, ttl)
+}
+
+// Get JSON-decodes the stored value for key into out. It returns
+// ErrStateNotFound if nothing is stored.
+func (s *QuestState) Get(key string, out any) error {
+	zoneID, instanceID := s.coords()
+	data, err := s.evt.zone.ensurePersistence().Get(zoneID, instanceID, s.evt.Name, s.playerID(), key)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// Delete removes the stored value for key, if any.
+func (s *QuestState) Delete(key string) error {
+	zoneID, instanceID := s.coords()
+	return s.evt.zone.ensurePersistence().Delete(zoneID, instanceID, s.evt.Name, s.playerID(), key)
+}
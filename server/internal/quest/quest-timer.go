@@ -0,0 +1,440 @@
+package quest
+
+import (
+	"container/heap"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// timerKey identifies a single timer: one entity may own several timers
+// distinguished by name (e.g. "respawn_guard", "regen_tic").
+type timerKey struct {
+	EntityID int64
+	Name     string
+}
+
+// timerState is one entry in the manager's min-heap, ordered by fireAt.
+type timerState struct {
+	key       timerKey
+	id        uint32
+	duration  time.Duration // interval used to reschedule on fire/resume
+	fireAt    time.Time
+	repeat    bool
+	paused    bool
+	remaining time.Duration // set only while paused
+	index     int           // maintained by container/heap
+}
+
+// timerHeap is a container/heap.Interface ordering timerState by fireAt.
+type timerHeap []*timerState
+
+func (h timerHeap) Len() int            { return len(h) }
+func (h timerHeap) Less(i, j int) bool  { return h[i].fireAt.Before(h[j].fireAt) }
+func (h timerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+func (h *timerHeap) Push(x any) {
+	ts := x.(*timerState)
+	ts.index = len(*h)
+	*h = append(*h, ts)
+}
+func (h *timerHeap) Pop() any {
+	old := *h
+	n := len(old)
+	ts := old[n-1]
+	old[n-1] = nil
+	ts.index = -1
+	*h = old[:n-1]
+	return ts
+}
+
+// TimerRecord is the persisted shape of one timer, keyed by
+// (zoneID, instanceID, entityID, name) so a zone restart can restore
+// in-flight timers from TimerStore.
+type TimerRecord struct {
+	ZoneID     int
+	InstanceID int
+	EntityID   int64
+	Name       string
+	Duration   time.Duration
+	FireAt     time.Time
+	Repeat     bool
+	Paused     bool
+	Remaining  time.Duration
+}
+
+// TimerStore persists QuestTimerManager state so in-flight timers survive a
+// zone restart. The default is an in-memory store; callers needing durable
+// persistence across process restarts provide their own.
+type TimerStore interface {
+	Save(rec TimerRecord) error
+	Delete(zoneID, instanceID int, entityID int64, name string) error
+	Load(zoneID, instanceID int) ([]TimerRecord, error)
+}
+
+// timerStoreKey identifies one persisted timer record by the same
+// (zoneID, instanceID, entityID, name) tuple TimerRecord and TimerStore.Load
+// key on, so records from different zone instances never collide.
+type timerStoreKey struct {
+	ZoneID     int
+	InstanceID int
+	EntityID   int64
+	Name       string
+}
+
+// memoryTimerStore is the default TimerStore: it keeps records in memory,
+// which restores timers across a QuestTimerManager restart within the same
+// process but not across a process restart.
+type memoryTimerStore struct {
+	mu      sync.Mutex
+	records map[timerStoreKey]TimerRecord
+}
+
+func newMemoryTimerStore() *memoryTimerStore {
+	return &memoryTimerStore{records: make(map[timerStoreKey]TimerRecord)}
+}
+
+func (s *memoryTimerStore) Save(rec TimerRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[timerStoreKey{ZoneID: rec.ZoneID, InstanceID: rec.InstanceID, EntityID: rec.EntityID, Name: rec.Name}] = rec
+	return nil
+}
+
+func (s *memoryTimerStore) Delete(zoneID, instanceID int, entityID int64, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, timerStoreKey{ZoneID: zoneID, InstanceID: instanceID, EntityID: entityID, Name: name})
+	return nil
+}
+
+func (s *memoryTimerStore) Load(zoneID, instanceID int) ([]TimerRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]TimerRecord, 0, len(s.records))
+	for _, rec := range s.records {
+		if rec.ZoneID == zoneID && rec.InstanceID == instanceID {
+			out = append(out, rec)
+		}
+	}
+	return out, nil
+}
+
+// QuestTimerManager backs EventTimer/EventTimerStart/EventTimerPause/
+// EventTimerResume/EventTimerStop. It holds one min-heap of pending timers
+// protected by mu, with a single goroutine parked on a time.Timer reset to
+// the heap head; on fire it synthesizes an EventTimer QuestEvent and
+// dispatches it through the zone's event bus.
+type QuestTimerManager struct {
+	z     *ZoneQuestInterface
+	store TimerStore
+
+	mu     sync.Mutex
+	heap   timerHeap
+	byKey  map[timerKey]*timerState
+	nextID uint32
+
+	wake     chan struct{}
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+func newQuestTimerManager(z *ZoneQuestInterface) *QuestTimerManager {
+	m := &QuestTimerManager{
+		z:      z,
+		store:  newMemoryTimerStore(),
+		byKey:  make(map[timerKey]*timerState),
+		wake:   make(chan struct{}, 1),
+		stopCh: make(chan struct{}),
+	}
+	go m.run()
+	return m
+}
+
+// SetStore overrides the timer persistence backend. Call it before starting
+// any timers; in-flight timers are not migrated between stores.
+func (m *QuestTimerManager) SetStore(store TimerStore) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store = store
+}
+
+// Stop shuts down the manager's background goroutine. Pending timers are
+// left in the store, so a fresh QuestTimerManager backed by the same store
+// can pick them up. Safe to call more than once.
+func (m *QuestTimerManager) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stopCh)
+	})
+}
+
+func (m *QuestTimerManager) zoneCoords() (zoneID, instanceID int) {
+	if m.z.ZoneAccess == nil {
+		return 0, 0
+	}
+	return m.z.ZoneAccess.GetZoneID(), m.z.ZoneAccess.GetInstanceID()
+}
+
+func (m *QuestTimerManager) dispatchName(entityID int64) string {
+	if m.z.ZoneAccess != nil {
+		if npc, ok := m.z.ZoneAccess.GetNPCByID(int(entityID)); ok {
+			return npc.GetName()
+		}
+	}
+	return strconv.FormatInt(entityID, 10)
+}
+
+func (m *QuestTimerManager) poke() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// StartTimer schedules a new timer on entityID. If repeat is true, the
+// timer reschedules itself for d after every fire instead of being removed.
+func (m *QuestTimerManager) StartTimer(entityID int64, name string, d time.Duration, repeat bool) {
+	key := timerKey{EntityID: entityID, Name: name}
+
+	m.mu.Lock()
+	m.nextID++
+	ts := &timerState{
+		key:      key,
+		id:       m.nextID,
+		duration: d,
+		fireAt:   time.Now().Add(d),
+		repeat:   repeat,
+	}
+	if old, ok := m.byKey[key]; ok && !old.paused {
+		heap.Remove(&m.heap, old.index)
+	}
+	m.byKey[key] = ts
+	heap.Push(&m.heap, ts)
+	m.persistLocked(ts)
+	m.mu.Unlock()
+
+	m.poke()
+	m.emit(EventTimerStart, entityID, name)
+}
+
+// PauseTimer suspends entityID's timer named name, remembering the time
+// left so ResumeTimer can pick up where it left off.
+func (m *QuestTimerManager) PauseTimer(entityID int64, name string) {
+	key := timerKey{EntityID: entityID, Name: name}
+
+	m.mu.Lock()
+	ts, ok := m.byKey[key]
+	if !ok || ts.paused {
+		m.mu.Unlock()
+		return
+	}
+	ts.remaining = time.Until(ts.fireAt)
+	ts.paused = true
+	heap.Remove(&m.heap, ts.index)
+	m.persistLocked(ts)
+	m.mu.Unlock()
+
+	m.emit(EventTimerPause, entityID, name)
+}
+
+// ResumeTimer re-arms a paused timer for its remaining duration.
+func (m *QuestTimerManager) ResumeTimer(entityID int64, name string) {
+	key := timerKey{EntityID: entityID, Name: name}
+
+	m.mu.Lock()
+	ts, ok := m.byKey[key]
+	if !ok || !ts.paused {
+		m.mu.Unlock()
+		return
+	}
+	ts.paused = false
+	ts.fireAt = time.Now().Add(ts.remaining)
+	heap.Push(&m.heap, ts)
+	m.persistLocked(ts)
+	m.mu.Unlock()
+
+	m.poke()
+	m.emit(EventTimerResume, entityID, name)
+}
+
+// StopTimer cancels entityID's timer named name, whether pending or paused.
+func (m *QuestTimerManager) StopTimer(entityID int64, name string) {
+	key := timerKey{EntityID: entityID, Name: name}
+
+	m.mu.Lock()
+	ts, ok := m.byKey[key]
+	if !ok {
+		m.mu.Unlock()
+		return
+	}
+	if !ts.paused {
+		heap.Remove(&m.heap, ts.index)
+	}
+	delete(m.byKey, key)
+	zoneID, instanceID := m.zoneCoords()
+	m.store.Delete(zoneID, instanceID, entityID, name)
+	m.mu.Unlock()
+
+	m.emit(EventTimerStop, entityID, name)
+}
+
+// RemainingTime reports how long until entityID's timer named name fires.
+// ok is false if no such timer exists.
+func (m *QuestTimerManager) RemainingTime(entityID int64, name string) (remaining time.Duration, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ts, found := m.byKey[timerKey{EntityID: entityID, Name: name}]
+	if !found {
+		return 0, false
+	}
+	if ts.paused {
+		return ts.remaining, true
+	}
+	return time.Until(ts.fireAt), true
+}
+
+// persistLocked writes ts to the configured store. Caller must hold m.mu.
+func (m *QuestTimerManager) persistLocked(ts *timerState) {
+	zoneID, instanceID := m.zoneCoords()
+	m.store.Save(TimerRecord{
+		ZoneID:     zoneID,
+		InstanceID: instanceID,
+		EntityID:   ts.key.EntityID,
+		Name:       ts.key.Name,
+		Duration:   ts.duration,
+		FireAt:     ts.fireAt,
+		Repeat:     ts.repeat,
+		Paused:     ts.paused,
+		Remaining:  ts.remaining,
+	})
+}
+
+// Restore reloads pending timers for the zone's current (zoneID,
+// instanceID) from the configured store, re-arming them against the heap.
+// Call it once after the zone and its TimerStore are wired up.
+func (m *QuestTimerManager) Restore() error {
+	zoneID, instanceID := m.zoneCoords()
+	records, err := m.store.Load(zoneID, instanceID)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	for _, rec := range records {
+		ts := &timerState{
+			key:       timerKey{EntityID: rec.EntityID, Name: rec.Name},
+			duration:  rec.Duration,
+			fireAt:    rec.FireAt,
+			repeat:    rec.Repeat,
+			paused:    rec.Paused,
+			remaining: rec.Remaining,
+		}
+		m.nextID++
+		ts.id = m.nextID
+		m.byKey[ts.key] = ts
+		if !ts.paused {
+			heap.Push(&m.heap, ts)
+		}
+	}
+	m.mu.Unlock()
+
+	m.poke()
+	return nil
+}
+
+// emit publishes the EventTimer* bookkeeping event for a lifecycle change.
+func (m *QuestTimerManager) emit(eventType QuestEventType, entityID int64, name string) {
+	m.z.Publish(&QuestEvent{
+		EventType:   eventType,
+		Name:        m.dispatchName(entityID),
+		ExtraData:   uint32(entityID),
+		StringArray: []string{name},
+	})
+}
+
+// run is the manager's single background goroutine: it sleeps until the
+// heap head is due, fires every timer whose fireAt has passed, reschedules
+// repeating timers, and otherwise blocks until poked by a Start/Resume.
+func (m *QuestTimerManager) run() {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		m.mu.Lock()
+		var wait time.Duration
+		if m.heap.Len() == 0 {
+			wait = time.Hour
+		} else {
+			wait = time.Until(m.heap[0].fireAt)
+			if wait < 0 {
+				wait = 0
+			}
+		}
+		m.mu.Unlock()
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+
+		select {
+		case <-m.stopCh:
+			return
+		case <-m.wake:
+		case <-timer.C:
+			m.fireDue()
+		}
+	}
+}
+
+func (m *QuestTimerManager) fireDue() {
+	now := time.Now()
+	var due []*timerState
+
+	m.mu.Lock()
+	for m.heap.Len() > 0 && !m.heap[0].fireAt.After(now) {
+		ts := heap.Pop(&m.heap).(*timerState)
+		due = append(due, ts)
+		if ts.repeat {
+			ts.fireAt = now.Add(ts.duration)
+			heap.Push(&m.heap, ts)
+			m.persistLocked(ts)
+		} else {
+			delete(m.byKey, ts.key)
+			zoneID, instanceID := m.zoneCoords()
+			m.store.Delete(zoneID, instanceID, ts.key.EntityID, ts.key.Name)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, ts := range due {
+		m.z.Publish(&QuestEvent{
+			EventType:   EventTimer,
+			Name:        m.dispatchName(ts.key.EntityID),
+			ExtraData:   ts.id,
+			StringArray: []string{ts.key.Name},
+		})
+	}
+}
+
+// Timers returns the zone's QuestTimerManager, starting it on first use.
+func (z *ZoneQuestInterface) Timers() *QuestTimerManager {
+	z.timerOnce.Do(func() {
+		z.timerMgr = newQuestTimerManager(z)
+	})
+	return z.timerMgr
+}
+
+// CloseTimers stops the zone's QuestTimerManager goroutine, if one was ever
+// started. Call it when tearing down a transient zone instance so its timer
+// goroutine doesn't leak.
+func (z *ZoneQuestInterface) CloseTimers() {
+	if z.timerMgr != nil {
+		z.timerMgr.Stop()
+	}
+}
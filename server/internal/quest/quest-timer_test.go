@@ -0,0 +1,33 @@
+package quest
+
+import (
+	"testing"
+	"time"
+)
+
+// TestRestartPausedTimer reproduces StartTimer being called again for a key
+// whose timer is currently paused (index == -1 after PauseTimer's
+// heap.Remove). Before the guard this panicked inside heap.Remove (here in
+// timerHeap.Pop, since the paused timer left the heap empty).
+func TestRestartPausedTimer(t *testing.T) {
+	z := &ZoneQuestInterface{}
+	m := z.Timers()
+
+	m.StartTimer(1, "regen_tic", time.Minute, false)
+	m.PauseTimer(1, "regen_tic")
+
+	m.StartTimer(1, "regen_tic", 2*time.Minute, false)
+
+	m.mu.Lock()
+	ts, ok := m.byKey[timerKey{EntityID: 1, Name: "regen_tic"}]
+	m.mu.Unlock()
+	if !ok {
+		t.Fatalf("timer not found after restart")
+	}
+	if ts.paused {
+		t.Fatalf("restarted timer is still marked paused")
+	}
+	if ts.index < 0 {
+		t.Fatalf("restarted timer has no heap index: %d", ts.index)
+	}
+}
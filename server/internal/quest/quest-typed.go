@@ -0,0 +1,160 @@
+package quest
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/knervous/eqgo/internal/db/items"
+	entity "github.com/knervous/eqgo/internal/entity"
+)
+
+// Per-event payload structs give quest authors a typed view over the
+// handful of QuestEvent fields relevant to a given event, instead of
+// reading from shared optional fields like ExtraData, SpellID, and
+// StringArray.
+
+type SayEvent struct {
+	Speaker  entity.Moblike
+	Text     string
+	Language uint32
+}
+
+type TradeEvent struct {
+	Actor    entity.Moblike
+	Receiver entity.Moblike
+	Items    []items.ItemInstance
+}
+
+type SlayEvent struct {
+	Actor    entity.Moblike
+	Receiver entity.Moblike
+}
+
+type WaypointEvent struct {
+	Actor      entity.Moblike
+	WaypointID uint32
+}
+
+type LootEvent struct {
+	Actor    entity.Moblike
+	Receiver entity.Moblike
+	Items    []items.ItemInstance
+}
+
+type TimerEvent struct {
+	EntityID uint32
+	Name     string
+}
+
+type SpellEffectEvent struct {
+	Actor    entity.Moblike
+	Receiver entity.Moblike
+	SpellID  uint32
+}
+
+// TypedQuestHandler is a QuestHandler scoped to a single concrete payload
+// type E, mirroring controller-runtime's TypedEventHandler layered over the
+// untyped EventHandler.
+type TypedQuestHandler[E any] func(*E) bool
+
+// typedEventKinds enumerates, for each payload type, the QuestEventTypes it
+// is valid to register against. RegisterTyped consults this at registration
+// time so a mismatched payload/event pair panics immediately rather than
+// silently misreading fields at dispatch time.
+var typedEventKinds = map[reflect.Type][]QuestEventType{
+	reflect.TypeOf(SayEvent{}):         {EventSay, EventAggroSay, EventProximitySay},
+	reflect.TypeOf(TradeEvent{}):       {EventTrade},
+	reflect.TypeOf(SlayEvent{}):        {EventSlay, EventNpcSlay},
+	reflect.TypeOf(WaypointEvent{}):    {EventWaypointArrive, EventWaypointDepart},
+	reflect.TypeOf(LootEvent{}):        {EventLoot, EventLootZone},
+	reflect.TypeOf(TimerEvent{}):       {EventTimer, EventTimerStart, EventTimerPause, EventTimerResume, EventTimerStop},
+	reflect.TypeOf(SpellEffectEvent{}): {EventSpellEffectClient, EventSpellEffectNpc, EventSpellEffectBuffTicClient, EventSpellEffectBuffTicNpc},
+}
+
+// typedEventBuilders converts a *QuestEvent into the concrete payload for a
+// given payload type, returned as an any so RegisterTyped can type-assert it
+// back to E.
+var typedEventBuilders = map[reflect.Type]func(*QuestEvent) any{
+	reflect.TypeOf(SayEvent{}): func(evt *QuestEvent) any {
+		text := ""
+		if len(evt.StringArray) > 0 {
+			text = evt.StringArray[0]
+		}
+		return SayEvent{Speaker: evt.Actor, Text: text, Language: evt.ExtraData}
+	},
+	reflect.TypeOf(TradeEvent{}): func(evt *QuestEvent) any {
+		return TradeEvent{Actor: evt.Actor, Receiver: evt.Receiver, Items: derefItems(evt.ItemArray)}
+	},
+	reflect.TypeOf(SlayEvent{}): func(evt *QuestEvent) any {
+		return SlayEvent{Actor: evt.Actor, Receiver: evt.Receiver}
+	},
+	reflect.TypeOf(WaypointEvent{}): func(evt *QuestEvent) any {
+		return WaypointEvent{Actor: evt.Actor, WaypointID: evt.ExtraData}
+	},
+	reflect.TypeOf(LootEvent{}): func(evt *QuestEvent) any {
+		return LootEvent{Actor: evt.Actor, Receiver: evt.Receiver, Items: derefItems(evt.ItemArray)}
+	},
+	reflect.TypeOf(TimerEvent{}): func(evt *QuestEvent) any {
+		name := ""
+		if len(evt.StringArray) > 0 {
+			name = evt.StringArray[0]
+		}
+		return TimerEvent{EntityID: evt.ExtraData, Name: name}
+	},
+	reflect.TypeOf(SpellEffectEvent{}): func(evt *QuestEvent) any {
+		return SpellEffectEvent{Actor: evt.Actor, Receiver: evt.Receiver, SpellID: evt.SpellID}
+	},
+}
+
+func derefItems(itemArray *[]items.ItemInstance) []items.ItemInstance {
+	if itemArray == nil {
+		return nil
+	}
+	return *itemArray
+}
+
+// RegisterTyped registers a handler that receives the typed payload E
+// instead of the raw *QuestEvent. The event-type/payload pairing is checked
+// once at registration time via typedEventKinds, so a mismatch panics here
+// instead of producing a handler that silently reads zero-valued fields.
+func RegisterTyped[E any](z *ZoneQuestInterface, name string, evt QuestEventType, h TypedQuestHandler[E]) {
+	var zero E
+	t := reflect.TypeOf(zero)
+
+	allowed, ok := typedEventKinds[t]
+	if !ok {
+		panic(fmt.Sprintf("quest: %T has no registered payload mapping", zero))
+	}
+	valid := false
+	for _, a := range allowed {
+		if a == evt {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		panic(fmt.Sprintf("quest: %T is not a valid payload for event %d", zero, evt))
+	}
+
+	build := typedEventBuilders[t]
+	z.Register(name, evt, QuestHandler(func(qe *QuestEvent) bool {
+		payload := qe.typedPayload(t, build).(E)
+		return h(&payload)
+	}))
+}
+
+// typedPayload returns qe's payload for t, building it via build on first
+// use and memoizing it so every RegisterTyped handler invoked for this
+// dispatch shares a single built payload instead of each re-deriving its
+// own from the raw QuestEvent fields.
+func (qe *QuestEvent) typedPayload(t reflect.Type, build func(*QuestEvent) any) any {
+	if v, ok := qe.typedPayloads[t]; ok {
+		return v
+	}
+	v := build(qe)
+	if qe.typedPayloads == nil {
+		qe.typedPayloads = make(map[reflect.Type]any, 1)
+	}
+	qe.typedPayloads[t] = v
+	return v
+}